@@ -4,174 +4,377 @@ package cache
 // It was created as an exmmple to illustrate property-based testing.
 
 import (
-	"container/list"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // Config holds cache configuration options
-type Config struct {
+type Config[K comparable, V any] struct {
 	MaxSize int
 
 	// If 0, items without explicit expiration won't expire
 	DefaultTTL time.Duration
+
+	// Policy controls which entries get tracked, promoted, and evicted. If
+	// nil, NewWithConfig falls back to NewLRUPolicy(MaxSize).
+	Policy EvictionPolicy[K, V]
+
+	// Clock supplies the current time for expiration checks. If nil,
+	// NewWithConfig falls back to the wall clock.
+	Clock Clock
 }
 
 // DefaultConfig returns the default cache configuration
-func DefaultConfig() Config {
-	return Config{
+func DefaultConfig[K comparable, V any]() Config[K, V] {
+	return Config[K, V]{
 		MaxSize:    1000,
 		DefaultTTL: 24 * time.Hour,
 	}
 }
 
 // Item represents a cache entry with value and expiration
-type Item struct {
-	Value      any
+type Item[V any] struct {
+	Value      V
 	Expiration int64
 }
 
-// Cache provides a thread-safe cache with expiration and LRU eviction
-type Cache struct {
-	items  sync.Map
-	lru    *list.List
-	keyMap map[string]*list.Element
+// Cache provides a thread-safe cache with expiration and pluggable eviction
+type Cache[K comparable, V any] struct {
+	items  atomic.Pointer[sync.Map]
+	policy EvictionPolicy[K, V]
 	lock   sync.Mutex
-	config Config
+	config Config[K, V]
 	done   chan struct{}
+	loads  singleflight.Group
+	clock  Clock
+
+	expQueue expirationQueue[K]
+	expIndex map[K]*expirationEntry[K]
+	timerCh  chan time.Duration
+
+	handlersMu  sync.RWMutex
+	onInsertion func(key K, value V)
+	onEviction  func(reason EvictionReason, key K, value V)
+	events      chan event[K, V]
+	eventsDone  chan struct{}
+
+	stats cacheStats
 }
 
-func New() *Cache {
-	return NewWithConfig(DefaultConfig())
+func New[K comparable, V any]() *Cache[K, V] {
+	return NewWithConfig[K, V](DefaultConfig[K, V]())
 }
 
-func NewWithConfig(config Config) *Cache {
-	cache := &Cache{
-		lru:    list.New(),
-		keyMap: make(map[string]*list.Element),
-		config: config,
-		done:   make(chan struct{}),
+func NewWithConfig[K comparable, V any](config Config[K, V]) *Cache[K, V] {
+	policy := config.Policy
+	if policy == nil {
+		policy = NewLRUPolicy[K, V](config.MaxSize)
+	}
+	clock := config.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	cache := &Cache[K, V]{
+		policy:     policy,
+		config:     config,
+		clock:      clock,
+		done:       make(chan struct{}),
+		expIndex:   make(map[K]*expirationEntry[K]),
+		timerCh:    make(chan time.Duration, 1),
+		events:     make(chan event[K, V], eventBufferSize),
+		eventsDone: make(chan struct{}),
 	}
+	cache.items.Store(&sync.Map{})
 	go cache.janitor()
+	go cache.dispatchEvents()
 	return cache
 }
 
+// itemsMap returns the sync.Map currently backing the cache's storage. Clear
+// swaps in a fresh one atomically, so callers must re-fetch it rather than
+// holding onto a reference across a call that might race with Clear.
+func (c *Cache[K, V]) itemsMap() *sync.Map {
+	return c.items.Load()
+}
+
 // Set adds a key-value pair to the cache with optional expiration
-func (c *Cache) Set(key string, value any, duration time.Duration) {
+func (c *Cache[K, V]) Set(key K, value V, duration time.Duration) {
 	var exp int64
 	if duration > 0 {
-		exp = time.Now().Add(duration).UnixNano()
+		exp = c.clock.Now().Add(duration).UnixNano()
 	} else if c.config.DefaultTTL > 0 {
-		exp = time.Now().Add(c.config.DefaultTTL).UnixNano()
+		exp = c.clock.Now().Add(c.config.DefaultTTL).UnixNano()
 	}
 
+	var evictedKeys []K
+	var evictedValues []V
+
 	c.lock.Lock()
-	if elem, exists := c.keyMap[key]; exists {
-		c.lru.Remove(elem)
-		delete(c.keyMap, key)
-	}
-	elem := c.lru.PushFront(key)
-	c.keyMap[key] = elem
-
-	if c.config.MaxSize > 0 && c.lru.Len() > c.config.MaxSize {
-		if back := c.lru.Back(); back != nil {
-			evictKey := back.Value.(string)
-			c.lru.Remove(back)
-			delete(c.keyMap, evictKey)
-			c.items.Delete(evictKey)
+	c.policy.OnInsert(key, value)
+	c.trackExpirationLocked(key, exp)
+
+	for c.policy.ShouldEvict() {
+		victim, ok := c.policy.EvictOne(key)
+		if !ok {
+			break
+		}
+		if itemObj, exists := c.itemsMap().Load(victim); exists {
+			evictedValues = append(evictedValues, itemObj.(Item[V]).Value)
+		} else {
+			var zero V
+			evictedValues = append(evictedValues, zero)
 		}
+		evictedKeys = append(evictedKeys, victim)
+		c.untrackExpirationLocked(victim)
+		c.itemsMap().Delete(victim)
+		c.stats.evictions.Add(1)
 	}
 	c.lock.Unlock()
 
-	c.items.Store(key, Item{
+	c.itemsMap().Store(key, Item[V]{
 		Value:      value,
 		Expiration: exp,
 	})
+	c.stats.insertions.Add(1)
+
+	c.emitInsertion(key, value)
+	for i, evictedKey := range evictedKeys {
+		c.emitEviction(ReasonCapacity, evictedKey, evictedValues[i])
+	}
+	c.notifyJanitor()
 }
 
 // Get retrieves a value from the cache
-func (c *Cache) Get(key string) (any, bool) {
-	itemObj, exists := c.items.Load(key)
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var zero V
+
+	itemObj, exists := c.itemsMap().Load(key)
 	if !exists {
-		return nil, false
+		c.stats.misses.Add(1)
+		return zero, false
 	}
 
-	item := itemObj.(Item)
+	item := itemObj.(Item[V])
 
-	if item.Expiration > 0 && time.Now().UnixNano() > item.Expiration {
-		c.Delete(key) // This handles LRU and sync.Map deletion
-		return nil, false
+	if item.Expiration > 0 && c.clock.Now().UnixNano() > item.Expiration {
+		c.delete(key, ReasonExpired) // This handles policy and sync.Map deletion
+		c.stats.misses.Add(1)
+		return zero, false
 	}
 
 	c.lock.Lock()
-	elem, lruExists := c.keyMap[key]
-	if lruExists {
-		c.lru.MoveToFront(elem)
+	tracked := c.policy.Contains(key)
+	if tracked {
+		c.policy.OnAccess(key)
 	}
 	c.lock.Unlock()
 
-	// If the item was found in items but not in the LRU map (meaning it was
-	// concurrently evicted/deleted after items.Load but before lock was acquired),
-	// consider it not found.
-	if !lruExists {
-		return nil, false
+	// If the item was found in items but the policy no longer tracks it
+	// (meaning it was concurrently evicted/deleted after items.Load but
+	// before the lock was acquired), consider it not found.
+	if !tracked {
+		c.stats.misses.Add(1)
+		return zero, false
 	}
 
+	c.stats.hits.Add(1)
 	return item.Value, true
 }
 
+// GetOrLoad returns the cached value for key if present, otherwise it calls
+// loader exactly once and stores the result under key with the given ttl
+// before returning it. Concurrent misses for the same key are collapsed into
+// a single loader call; every caller waiting on that call receives its
+// result, but a loader error is never cached, so the next miss retries.
+func (c *Cache[K, V]) GetOrLoad(key K, ttl time.Duration, loader func() (V, error)) (V, error) {
+	if value, exists := c.Get(key); exists {
+		return value, nil
+	}
+
+	value, err, _ := c.loads.Do(fmt.Sprint(key), func() (any, error) {
+		value, err := loader()
+		if err != nil {
+			return value, err
+		}
+		c.Set(key, value, ttl)
+		return value, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	return value.(V), nil
+}
+
 // Delete removes a key from the cache
-func (c *Cache) Delete(key string) {
+func (c *Cache[K, V]) Delete(key K) {
+	c.delete(key, ReasonDeleted)
+}
+
+// delete removes key from the cache, firing an eviction event with reason
+// if the key was actually present.
+func (c *Cache[K, V]) delete(key K, reason EvictionReason) {
 	c.lock.Lock()
-	if elem, exists := c.keyMap[key]; exists {
-		c.lru.Remove(elem)
-		delete(c.keyMap, key)
-	}
+	c.policy.OnRemove(key)
+	c.untrackExpirationLocked(key)
 	c.lock.Unlock()
 
-	c.items.Delete(key)
+	if itemObj, existed := c.itemsMap().LoadAndDelete(key); existed {
+		switch reason {
+		case ReasonDeleted:
+			c.stats.deletions.Add(1)
+		case ReasonExpired:
+			c.stats.expirations.Add(1)
+		}
+		c.emitEviction(reason, key, itemObj.(Item[V]).Value)
+	}
 }
 
 // Clear removes all items from the cache
-func (c *Cache) Clear() {
+func (c *Cache[K, V]) Clear() {
 	c.lock.Lock()
-	c.lru = list.New()
-	c.keyMap = make(map[string]*list.Element)
+	c.policy.Clear()
+	c.expQueue = nil
+	c.expIndex = make(map[K]*expirationEntry[K])
 	c.lock.Unlock()
 
-	c.items = sync.Map{}
+	// Swap in a fresh map atomically rather than reassigning c.items in
+	// place, so a concurrent Get/Set/delete that already loaded the old map
+	// via itemsMap() keeps operating on a live sync.Map instead of racing
+	// with this reassignment.
+	old := c.items.Swap(&sync.Map{})
+
+	var keys []K
+	var values []V
+	old.Range(func(k, v any) bool {
+		keys = append(keys, k.(K))
+		values = append(values, v.(Item[V]).Value)
+		return true
+	})
+
+	for i, key := range keys {
+		c.emitEviction(ReasonCleared, key, values[i])
+	}
 }
 
 // Len returns the current number of items in the cache
-func (c *Cache) Len() int {
+func (c *Cache[K, V]) Len() int {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	return c.lru.Len()
+	return c.policy.Len()
 }
 
-// Stop cleanly shuts down the cache and stops the janitor
-func (c *Cache) Stop() {
+// Stop cleanly shuts down the cache, stops the janitor, and waits for all
+// pending event callbacks to be dispatched before returning. It's safe to
+// call concurrently with in-flight Set/Delete/Clear calls from other
+// goroutines: neither timerCh nor events is ever closed, so a racing send
+// from notifyJanitor/emitInsertion/emitEviction can't panic against them.
+func (c *Cache[K, V]) Stop() {
 	close(c.done)
+	<-c.eventsDone
 }
 
-// janitor periodically cleans up expired items
-func (c *Cache) janitor() {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
+// janitor sleeps until the soonest tracked expiration is due, wakes to
+// delete it (and any other entries that became due in the meantime), then
+// sleeps until the new soonest expiration. Set wakes it early via timerCh
+// whenever it learns of an earlier expiration than the one it's waiting on.
+func (c *Cache[K, V]) janitor() {
+	var timer *time.Timer
+	var timerC <-chan time.Time
+
+	stopTimer := func() {
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+			timerC = nil
+		}
+	}
+	defer stopTimer()
 
 	for {
 		select {
-		case <-ticker.C:
-			now := time.Now().UnixNano()
-			c.items.Range(func(key, value any) bool {
-				item := value.(Item)
-				if item.Expiration > 0 && now > item.Expiration {
-					c.Delete(key.(string))
-				}
-				return true
-			})
+		case d := <-c.timerCh:
+			stopTimer()
+			timer = time.NewTimer(d)
+			timerC = timer.C
+		case <-timerC:
+			c.expireDue()
+			stopTimer()
+			if next, ok := c.nextExpirationWait(); ok {
+				timer = time.NewTimer(next)
+				timerC = timer.C
+			}
 		case <-c.done:
 			return
 		}
 	}
 }
+
+// expireDue deletes every entry whose expiration is due, re-checking the
+// heap root under the lock each time so a concurrent Set that refreshed a
+// key's TTL is never deleted out from under it.
+func (c *Cache[K, V]) expireDue() {
+	now := c.clock.Now().UnixNano()
+	for {
+		c.lock.Lock()
+		// Stop at exp >= now, matching Get/Peek's strict "now > Expiration"
+		// check: an entry due at exactly now isn't expired yet by that
+		// definition, so the janitor shouldn't delete it out from under a
+		// concurrent Get that would still consider it live.
+		exp, ok := c.nextExpirationLocked()
+		if !ok || exp >= now {
+			c.lock.Unlock()
+			return
+		}
+		key := c.expQueue[0].key
+		c.lock.Unlock()
+
+		c.delete(key, ReasonExpired)
+	}
+}
+
+// nextExpirationWait returns how long to wait until the soonest tracked
+// expiration is due.
+func (c *Cache[K, V]) nextExpirationWait() (time.Duration, bool) {
+	c.lock.Lock()
+	exp, ok := c.nextExpirationLocked()
+	c.lock.Unlock()
+	if !ok {
+		return 0, false
+	}
+	d := time.Unix(0, exp).Sub(c.clock.Now())
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+// notifyJanitor wakes the janitor if it should now be waiting on a different
+// expiration than before; it's a no-op if nothing is tracked. timerCh is
+// never closed (see Stop), so this only needs to bail out once Stop has
+// been called, not guard against a send-on-closed-channel panic.
+func (c *Cache[K, V]) notifyJanitor() {
+	d, ok := c.nextExpirationWait()
+	if !ok {
+		return
+	}
+	for {
+		select {
+		case c.timerCh <- d:
+			return
+		case <-c.done:
+			return
+		default:
+		}
+		select {
+		case <-c.timerCh:
+		default:
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_InsertionAndEvictionEvents(t *testing.T) {
+	cache := NewWithConfig[string, string](Config[string, string]{
+		MaxSize:    2,
+		DefaultTTL: time.Hour,
+	})
+
+	var mu sync.Mutex
+	var inserted []string
+	var evicted []EvictionReason
+
+	cache.OnInsertion(func(key string, value string) {
+		mu.Lock()
+		inserted = append(inserted, key)
+		mu.Unlock()
+	})
+	cache.OnEviction(func(reason EvictionReason, key string, value string) {
+		mu.Lock()
+		evicted = append(evicted, reason)
+		mu.Unlock()
+	})
+
+	cache.Set("1", "one", 0)
+	cache.Set("2", "two", 0)
+	cache.Set("3", "three", 0) // evicts "1" for capacity
+	cache.Delete("2")
+	cache.Clear()
+
+	cache.Stop() // wait for all pending events to drain
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"1", "2", "3"}, inserted)
+	require.Contains(t, evicted, ReasonCapacity)
+	require.Contains(t, evicted, ReasonDeleted)
+	require.Contains(t, evicted, ReasonCleared)
+}
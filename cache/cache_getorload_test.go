@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_GetOrLoad_CollapsesConcurrentMisses(t *testing.T) {
+	cache := New[string, string]()
+	defer cache.Stop()
+
+	var calls int32
+	const goroutines = 50
+	var wg sync.WaitGroup
+	results := make([]string, goroutines)
+	errs := make([]error, goroutines)
+
+	start := make(chan struct{})
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = cache.GetOrLoad("key", time.Hour, func() (string, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return "loaded", nil
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls), "loader should run exactly once for concurrent misses")
+	for i := 0; i < goroutines; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, "loaded", results[i])
+	}
+
+	val, exists := cache.Get("key")
+	require.True(t, exists)
+	require.Equal(t, "loaded", val)
+}
+
+func TestCache_GetOrLoad_ErrorNotCached(t *testing.T) {
+	cache := New[string, string]()
+	defer cache.Stop()
+
+	errLoad := errors.New("load failed")
+
+	_, err := cache.GetOrLoad("key", time.Hour, func() (string, error) {
+		return "", errLoad
+	})
+	require.ErrorIs(t, err, errLoad)
+
+	_, exists := cache.Get("key")
+	require.False(t, exists, "a failed load must not populate the cache")
+
+	val, err := cache.GetOrLoad("key", time.Hour, func() (string, error) {
+		return "retried", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "retried", val)
+}
@@ -13,7 +13,7 @@ import (
 func TestProperty_ValueConsistency(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		// Use a small cache to force evictions
-		cache := NewWithConfig(Config{
+		cache := NewWithConfig[string, any](Config[string, any]{
 			MaxSize:    rapid.IntRange(2, 5).Draw(t, "cacheSize"),
 			DefaultTTL: time.Duration(rapid.Int64Range(50, 200).Draw(t, "defaultTTLMs")) * time.Millisecond,
 		})
@@ -108,7 +108,7 @@ func TestProperty_ValueConsistency(t *testing.T) {
 func TestProperty_DeliberateFailure(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		// Create a cache that's deliberately too small
-		cache := NewWithConfig(Config{
+		cache := NewWithConfig[string, string](Config[string, string]{
 			MaxSize:    2,
 			DefaultTTL: time.Hour,
 		})
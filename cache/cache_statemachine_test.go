@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// fakeClock is a Clock whose time only moves when Advance is called, so
+// expiration in tests is driven by the state machine rather than real time.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// statemachineKeys bounds the key space so Set/Get/Delete collide with each
+// other often enough to exercise eviction and expiration.
+var statemachineKeys = []string{"a", "b", "c", "d", "e"}
+
+// modelEntry mirrors one entry of the cache under test: its value and the
+// wall-clock instant (per the fake clock) at which it expires. A zero
+// expiresAt means the entry never expires.
+type modelEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// cacheModel is a rapid.StateMachine whose commands exercise a Cache against
+// a plain-Go model of its expected LRU and expiration behavior. Check runs
+// after every command and verifies the cache never disagrees with the model.
+type cacheModel struct {
+	maxSize    int
+	defaultTTL time.Duration
+
+	cache *Cache[string, string]
+	clock *fakeClock
+
+	entries map[string]modelEntry
+	order   []string // front is most recently used
+}
+
+func newCacheModel(t *rapid.T) *cacheModel {
+	maxSize := rapid.IntRange(1, 5).Draw(t, "maxSize")
+	defaultTTL := time.Duration(rapid.Int64Range(0, 200).Draw(t, "defaultTTLMs")) * time.Millisecond
+	clock := newFakeClock()
+
+	cache := NewWithConfig[string, string](Config[string, string]{
+		MaxSize:    maxSize,
+		DefaultTTL: defaultTTL,
+		Clock:      clock,
+	})
+
+	return &cacheModel{
+		maxSize:    maxSize,
+		defaultTTL: defaultTTL,
+		cache:      cache,
+		clock:      clock,
+		entries:    make(map[string]modelEntry),
+	}
+}
+
+// touch moves key to the front of the model's recency order, inserting it if
+// it wasn't already tracked.
+func (m *cacheModel) touch(key string) {
+	m.drop(key)
+	m.order = append([]string{key}, m.order...)
+}
+
+// drop removes key from the model's recency order without touching entries.
+func (m *cacheModel) drop(key string) {
+	for i, k := range m.order {
+		if k == key {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// forget removes all trace of key from the model.
+func (m *cacheModel) forget(key string) {
+	delete(m.entries, key)
+	m.drop(key)
+}
+
+func (m *cacheModel) expired(entry modelEntry) bool {
+	return !entry.expiresAt.IsZero() && m.clock.Now().After(entry.expiresAt)
+}
+
+func (m *cacheModel) Set(t *rapid.T) {
+	key := rapid.SampledFrom(statemachineKeys).Draw(t, "key")
+	value := rapid.StringN(0, 8, 8).Draw(t, "value")
+	ttl := time.Duration(rapid.Int64Range(0, 300).Draw(t, "ttlMs")) * time.Millisecond
+
+	m.cache.Set(key, value, ttl)
+
+	effectiveTTL := ttl
+	if effectiveTTL == 0 {
+		effectiveTTL = m.defaultTTL
+	}
+	var expiresAt time.Time
+	if effectiveTTL > 0 {
+		expiresAt = m.clock.Now().Add(effectiveTTL)
+	}
+	m.entries[key] = modelEntry{value: value, expiresAt: expiresAt}
+	m.touch(key)
+
+	if m.maxSize > 0 && len(m.order) > m.maxSize {
+		victim := m.order[len(m.order)-1]
+		m.forget(victim)
+	}
+}
+
+func (m *cacheModel) Get(t *rapid.T) {
+	key := rapid.SampledFrom(statemachineKeys).Draw(t, "key")
+	got, exists := m.cache.Get(key)
+
+	entry, tracked := m.entries[key]
+	if tracked && m.expired(entry) {
+		m.forget(key)
+		tracked = false
+	}
+
+	if !tracked {
+		require.False(t, exists, "Get(%q) found a value the model doesn't have (expired or evicted)", key)
+		return
+	}
+
+	require.True(t, exists, "Get(%q) missing but model expects value %q", key, entry.value)
+	require.Equal(t, entry.value, got, "Get(%q) value mismatch", key)
+	m.touch(key)
+}
+
+func (m *cacheModel) Delete(t *rapid.T) {
+	key := rapid.SampledFrom(statemachineKeys).Draw(t, "key")
+	m.cache.Delete(key)
+	m.forget(key)
+}
+
+func (m *cacheModel) Clear(t *rapid.T) {
+	m.cache.Clear()
+	m.entries = make(map[string]modelEntry)
+	m.order = nil
+}
+
+func (m *cacheModel) Advance(t *rapid.T) {
+	d := time.Duration(rapid.Int64Range(0, 300).Draw(t, "advanceMs")) * time.Millisecond
+	m.clock.Advance(d)
+
+	// The background janitor wakes on a real-time timer, so without this it
+	// races the fake clock: a capacity eviction computed right after Advance
+	// could land on a stale victim the janitor hasn't physically swept yet.
+	// Forcing a synchronous sweep keeps the cache's tracked count in sync
+	// with the model, the same way the janitor itself would once its real
+	// timer got around to firing.
+	m.cache.expireDue()
+
+	// Mirror that sweep in the model's own bookkeeping: entries is the only
+	// place time passes, so this is the only place a model entry can newly
+	// become expired. Without forgetting it here, m.order would keep
+	// counting it toward maxSize long after the cache has physically
+	// dropped it, skewing which key the model expects the next capacity
+	// eviction to pick.
+	m.pruneExpired()
+}
+
+// pruneExpired forgets every model entry whose TTL has elapsed per the fake
+// clock, keeping m.order in sync with what the cache physically tracks.
+func (m *cacheModel) pruneExpired() {
+	for key, entry := range m.entries {
+		if m.expired(entry) {
+			m.forget(key)
+		}
+	}
+}
+
+// Check verifies, after every command, that the cache never exceeds MaxSize
+// and that every key it physically holds is one the model still expects
+// (i.e. not expired or LRU-evicted in the model).
+func (m *cacheModel) Check(t *rapid.T) {
+	if m.maxSize > 0 {
+		require.LessOrEqual(t, m.cache.Len(), m.maxSize)
+	}
+
+	for _, key := range m.cache.Keys() {
+		entry, tracked := m.entries[key]
+		require.True(t, tracked, "cache holds key %q the model no longer tracks", key)
+		if m.expired(entry) {
+			continue // the background janitor hasn't caught up to the fake clock yet
+		}
+		val, _ := m.cache.Peek(key)
+		require.Equal(t, entry.value, val, "cache value for key %q disagrees with model", key)
+	}
+}
+
+// TestProperty_StateMachine drives a Cache through random sequences of Set,
+// Get, Delete, Clear, and clock Advance commands, checking after every step
+// that its behavior matches a plain-Go LRU/expiration model. Failures shrink
+// to a minimal command sequence, which catches LRU/TTL interaction bugs that
+// TestProperty_ValueConsistency's one-shot style can miss.
+func TestProperty_StateMachine(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		m := newCacheModel(t)
+		defer m.cache.Stop()
+		t.Repeat(rapid.StateMachineActions(m))
+	})
+}
@@ -8,11 +8,11 @@ import (
 )
 
 func TestCache_SizeLimit(t *testing.T) {
-	config := Config{
+	config := Config[string, string]{
 		MaxSize:    2,
 		DefaultTTL: time.Hour,
 	}
-	cache := NewWithConfig(config)
+	cache := NewWithConfig[string, string](config)
 	defer func() {
 		cache.Stop()
 		cache.Clear()
@@ -57,11 +57,11 @@ func TestCache_SizeLimit(t *testing.T) {
 }
 
 func TestCache_DefaultTTL(t *testing.T) {
-	config := Config{
+	config := Config[string, string]{
 		MaxSize:    10,
 		DefaultTTL: 50 * time.Millisecond,
 	}
-	cache := NewWithConfig(config)
+	cache := NewWithConfig[string, string](config)
 	defer func() {
 		cache.Stop()
 		cache.Clear()
@@ -82,7 +82,7 @@ func TestCache_DefaultTTL(t *testing.T) {
 }
 
 func TestCache_ExplicitExpiration(t *testing.T) {
-	cache := New()
+	cache := New[string, string]()
 	defer func() {
 		cache.Stop()
 		cache.Clear()
@@ -103,7 +103,7 @@ func TestCache_ExplicitExpiration(t *testing.T) {
 }
 
 func TestCache_ClearAndLen(t *testing.T) {
-	cache := New()
+	cache := New[string, string]()
 	defer cache.Stop()
 
 	cache.Set("1", "one", 0)
@@ -117,8 +117,20 @@ func TestCache_ClearAndLen(t *testing.T) {
 	require.Equal(t, 1, cache.Len())
 }
 
+func TestCache_JanitorExpiresWithoutGet(t *testing.T) {
+	cache := New[string, string]()
+	defer cache.Stop()
+
+	cache.Set("key", "value", 30*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		_, exists := cache.itemsMap().Load("key")
+		return !exists
+	}, time.Second, 5*time.Millisecond, "janitor should expire the key on its own, without a Get")
+}
+
 func TestCache_Delete(t *testing.T) {
-	cache := New()
+	cache := New[string, string]()
 	defer cache.Stop()
 
 	cache.Set("key", "value", 0)
@@ -0,0 +1,13 @@
+package cache
+
+import "time"
+
+// Clock abstracts away time.Now so tests can drive expiration deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock, backed by the wall clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
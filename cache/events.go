@@ -0,0 +1,127 @@
+package cache
+
+// eventBufferSize bounds how many pending callbacks can queue up before a
+// producer (Set/Delete/Clear/the janitor) blocks waiting for the dispatch
+// worker to catch up.
+const eventBufferSize = 256
+
+// EvictionReason distinguishes why an entry left the cache.
+type EvictionReason int
+
+const (
+	// ReasonExpired means the entry's TTL elapsed.
+	ReasonExpired EvictionReason = iota
+	// ReasonCapacity means the entry was evicted to make room under MaxSize.
+	ReasonCapacity
+	// ReasonDeleted means the entry was removed by an explicit Delete call.
+	ReasonDeleted
+	// ReasonCleared means the entry was removed by a Clear call.
+	ReasonCleared
+)
+
+type eventKind int
+
+const (
+	eventInsertion eventKind = iota
+	eventEviction
+)
+
+type event[K comparable, V any] struct {
+	kind   eventKind
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// OnInsertion registers fn to be called whenever a key is added or updated
+// via Set. Only one handler can be registered at a time; calling OnInsertion
+// again replaces the previous handler.
+func (c *Cache[K, V]) OnInsertion(fn func(key K, value V)) {
+	c.handlersMu.Lock()
+	c.onInsertion = fn
+	c.handlersMu.Unlock()
+}
+
+// OnEviction registers fn to be called whenever a key leaves the cache,
+// whether by explicit deletion, TTL expiration, LRU eviction, or Clear. Only
+// one handler can be registered at a time; calling OnEviction again replaces
+// the previous handler.
+func (c *Cache[K, V]) OnEviction(fn func(reason EvictionReason, key K, value V)) {
+	c.handlersMu.Lock()
+	c.onEviction = fn
+	c.handlersMu.Unlock()
+}
+
+func (c *Cache[K, V]) emitInsertion(key K, value V) {
+	c.handlersMu.RLock()
+	handler := c.onInsertion
+	c.handlersMu.RUnlock()
+	if handler == nil {
+		return
+	}
+	ev := event[K, V]{kind: eventInsertion, key: key, value: value}
+	select {
+	case c.events <- ev:
+	case <-c.done:
+	}
+}
+
+func (c *Cache[K, V]) emitEviction(reason EvictionReason, key K, value V) {
+	c.handlersMu.RLock()
+	handler := c.onEviction
+	c.handlersMu.RUnlock()
+	if handler == nil {
+		return
+	}
+	ev := event[K, V]{kind: eventEviction, key: key, value: value, reason: reason}
+	select {
+	case c.events <- ev:
+	case <-c.done:
+	}
+}
+
+// dispatchCallback invokes the registered handler for ev outside of c.lock,
+// so a slow or misbehaving handler cannot block cache operations.
+func (c *Cache[K, V]) dispatchCallback(ev event[K, V]) {
+	switch ev.kind {
+	case eventInsertion:
+		c.handlersMu.RLock()
+		handler := c.onInsertion
+		c.handlersMu.RUnlock()
+		if handler != nil {
+			handler(ev.key, ev.value)
+		}
+	case eventEviction:
+		c.handlersMu.RLock()
+		handler := c.onEviction
+		c.handlersMu.RUnlock()
+		if handler != nil {
+			handler(ev.reason, ev.key, ev.value)
+		}
+	}
+}
+
+// dispatchEvents drains c.events and invokes the registered handlers until
+// done fires, at which point it drains whatever is still buffered before
+// exiting. c.events is never closed (emitInsertion/emitEviction send from
+// arbitrary caller goroutines, so closing it here would race with a
+// concurrent send), so this selects on done instead of ranging.
+func (c *Cache[K, V]) dispatchEvents() {
+	defer close(c.eventsDone)
+
+	for {
+		select {
+		case ev := <-c.events:
+			c.dispatchCallback(ev)
+		case <-c.done:
+			for {
+				select {
+				case ev := <-c.events:
+					c.dispatchCallback(ev)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package cache
+
+import "container/list"
+
+// EvictionPolicy decides which entries to track, promote, and evict. Cache
+// delegates all of that bookkeeping to a policy so it can keep its own
+// sync.Map + sync.Mutex structure regardless of the eviction strategy in
+// use. Every method is called with the owning Cache's lock held, so
+// implementations don't need their own synchronization.
+type EvictionPolicy[K comparable, V any] interface {
+	// OnInsert records a new or updated entry.
+	OnInsert(key K, value V)
+	// OnAccess records a read of key, e.g. for recency or frequency tracking.
+	OnAccess(key K)
+	// OnRemove drops any bookkeeping for key, however it left the cache.
+	OnRemove(key K)
+	// Contains reports whether the policy is currently tracking key.
+	Contains(key K) bool
+	// ShouldEvict reports whether the cache is over budget and EvictOne
+	// should be called (possibly repeatedly) before the next insert settles.
+	ShouldEvict() bool
+	// EvictOne picks a victim to evict, other than exclude (the key most
+	// recently passed to OnInsert, which should get a chance to stick around
+	// for at least one operation rather than being evicted by its own
+	// insertion). Returns false if no other key is tracked.
+	EvictOne(exclude K) (K, bool)
+	// Len returns the number of entries the policy is tracking.
+	Len() int
+	// Clear drops all bookkeeping, e.g. in response to Cache.Clear.
+	Clear()
+}
+
+// lruPolicy evicts the least-recently-accessed entry once tracking exceeds
+// maxSize. A maxSize of 0 means unbounded. This is the cache's original
+// behavior, now expressed as a policy.
+type lruPolicy[K comparable, V any] struct {
+	maxSize int
+	order   *list.List
+	elems   map[K]*list.Element
+}
+
+// NewLRUPolicy returns a policy that evicts the least-recently-accessed
+// entry when tracking more than maxSize keys (0 means unbounded).
+func NewLRUPolicy[K comparable, V any](maxSize int) EvictionPolicy[K, V] {
+	return &lruPolicy[K, V]{
+		maxSize: maxSize,
+		order:   list.New(),
+		elems:   make(map[K]*list.Element),
+	}
+}
+
+func (p *lruPolicy[K, V]) OnInsert(key K, _ V) {
+	if elem, exists := p.elems[key]; exists {
+		p.order.Remove(elem)
+	}
+	p.elems[key] = p.order.PushFront(key)
+}
+
+func (p *lruPolicy[K, V]) OnAccess(key K) {
+	if elem, exists := p.elems[key]; exists {
+		p.order.MoveToFront(elem)
+	}
+}
+
+func (p *lruPolicy[K, V]) OnRemove(key K) {
+	if elem, exists := p.elems[key]; exists {
+		p.order.Remove(elem)
+		delete(p.elems, key)
+	}
+}
+
+func (p *lruPolicy[K, V]) Contains(key K) bool {
+	_, exists := p.elems[key]
+	return exists
+}
+
+func (p *lruPolicy[K, V]) ShouldEvict() bool {
+	return p.maxSize > 0 && p.order.Len() > p.maxSize
+}
+
+func (p *lruPolicy[K, V]) EvictOne(exclude K) (K, bool) {
+	var zero K
+	elem := p.order.Back()
+	for elem != nil && elem.Value.(K) == exclude {
+		elem = elem.Prev()
+	}
+	if elem == nil {
+		return zero, false
+	}
+	key := elem.Value.(K)
+	p.order.Remove(elem)
+	delete(p.elems, key)
+	return key, true
+}
+
+func (p *lruPolicy[K, V]) Len() int { return p.order.Len() }
+
+func (p *lruPolicy[K, V]) Clear() {
+	p.order = list.New()
+	p.elems = make(map[K]*list.Element)
+}
+
+// lrcPolicy evicts the least-recently-created entry once tracking exceeds
+// maxSize: unlike lruPolicy, reads never promote an entry, so eviction order
+// follows insertion order alone.
+type lrcPolicy[K comparable, V any] struct {
+	*lruPolicy[K, V]
+}
+
+// NewLRCPolicy returns a policy that evicts the least-recently-created entry
+// when tracking more than maxSize keys (0 means unbounded). Gets never
+// affect eviction order.
+func NewLRCPolicy[K comparable, V any](maxSize int) EvictionPolicy[K, V] {
+	return &lrcPolicy[K, V]{lruPolicy: &lruPolicy[K, V]{
+		maxSize: maxSize,
+		order:   list.New(),
+		elems:   make(map[K]*list.Element),
+	}}
+}
+
+func (p *lrcPolicy[K, V]) OnAccess(K) {}
+
+// lfuPolicy evicts the least-frequently-accessed entry once tracking exceeds
+// maxSize.
+type lfuPolicy[K comparable, V any] struct {
+	maxSize int
+	freq    map[K]int
+}
+
+// NewLFUPolicy returns a policy that evicts the least-frequently-accessed
+// entry when tracking more than maxSize keys (0 means unbounded).
+func NewLFUPolicy[K comparable, V any](maxSize int) EvictionPolicy[K, V] {
+	return &lfuPolicy[K, V]{maxSize: maxSize, freq: make(map[K]int)}
+}
+
+func (p *lfuPolicy[K, V]) OnInsert(key K, _ V) {
+	if _, exists := p.freq[key]; !exists {
+		p.freq[key] = 0
+	}
+}
+
+func (p *lfuPolicy[K, V]) OnAccess(key K) {
+	if _, exists := p.freq[key]; exists {
+		p.freq[key]++
+	}
+}
+
+func (p *lfuPolicy[K, V]) OnRemove(key K) {
+	delete(p.freq, key)
+}
+
+func (p *lfuPolicy[K, V]) Contains(key K) bool {
+	_, exists := p.freq[key]
+	return exists
+}
+
+func (p *lfuPolicy[K, V]) ShouldEvict() bool {
+	return p.maxSize > 0 && len(p.freq) > p.maxSize
+}
+
+func (p *lfuPolicy[K, V]) EvictOne(exclude K) (K, bool) {
+	var victim K
+	found := false
+	min := 0
+	for key, f := range p.freq {
+		if key == exclude {
+			continue
+		}
+		if !found || f < min {
+			victim, min, found = key, f, true
+		}
+	}
+	if found {
+		delete(p.freq, victim)
+	}
+	return victim, found
+}
+
+func (p *lfuPolicy[K, V]) Len() int { return len(p.freq) }
+
+func (p *lfuPolicy[K, V]) Clear() { p.freq = make(map[K]int) }
+
+// sizePolicy bounds the cache by total value size rather than item count,
+// evicting least-recently-used entries first once tracking exceeds maxBytes.
+type sizePolicy[K comparable, V any] struct {
+	maxBytes int
+	sizeOf   func(value V) int
+	order    *list.List
+	elems    map[K]*list.Element
+	sizes    map[K]int
+	total    int
+}
+
+// NewByteSizePolicy returns a policy that bounds the cache by total value
+// size in bytes (as reported by sizeOf) rather than item count, evicting
+// least-recently-used entries first when over maxBytes (0 means unbounded).
+func NewByteSizePolicy[K comparable, V any](maxBytes int, sizeOf func(value V) int) EvictionPolicy[K, V] {
+	return &sizePolicy[K, V]{
+		maxBytes: maxBytes,
+		sizeOf:   sizeOf,
+		order:    list.New(),
+		elems:    make(map[K]*list.Element),
+		sizes:    make(map[K]int),
+	}
+}
+
+func (p *sizePolicy[K, V]) OnInsert(key K, value V) {
+	if elem, exists := p.elems[key]; exists {
+		p.order.Remove(elem)
+		p.total -= p.sizes[key]
+	}
+	size := p.sizeOf(value)
+	p.elems[key] = p.order.PushFront(key)
+	p.sizes[key] = size
+	p.total += size
+}
+
+func (p *sizePolicy[K, V]) OnAccess(key K) {
+	if elem, exists := p.elems[key]; exists {
+		p.order.MoveToFront(elem)
+	}
+}
+
+func (p *sizePolicy[K, V]) OnRemove(key K) {
+	if elem, exists := p.elems[key]; exists {
+		p.order.Remove(elem)
+		p.total -= p.sizes[key]
+		delete(p.sizes, key)
+		delete(p.elems, key)
+	}
+}
+
+func (p *sizePolicy[K, V]) Contains(key K) bool {
+	_, exists := p.elems[key]
+	return exists
+}
+
+func (p *sizePolicy[K, V]) ShouldEvict() bool {
+	return p.maxBytes > 0 && p.total > p.maxBytes
+}
+
+func (p *sizePolicy[K, V]) EvictOne(exclude K) (K, bool) {
+	var zero K
+	elem := p.order.Back()
+	for elem != nil && elem.Value.(K) == exclude {
+		elem = elem.Prev()
+	}
+	if elem == nil {
+		return zero, false
+	}
+	key := elem.Value.(K)
+	p.order.Remove(elem)
+	p.total -= p.sizes[key]
+	delete(p.sizes, key)
+	delete(p.elems, key)
+	return key, true
+}
+
+func (p *sizePolicy[K, V]) Len() int { return p.order.Len() }
+
+func (p *sizePolicy[K, V]) Clear() {
+	p.order = list.New()
+	p.elems = make(map[K]*list.Element)
+	p.sizes = make(map[K]int)
+	p.total = 0
+}
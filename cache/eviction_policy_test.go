@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_LRCPolicy_IgnoresAccessOrder(t *testing.T) {
+	cache := NewWithConfig[string, string](Config[string, string]{
+		MaxSize:    2,
+		DefaultTTL: time.Hour,
+		Policy:     NewLRCPolicy[string, string](2),
+	})
+	defer func() {
+		cache.Stop()
+		cache.Clear()
+	}()
+
+	cache.Set("1", "one", 0)
+	cache.Set("2", "two", 0)
+
+	// Unlike LRU, accessing "1" must not save it from eviction: order
+	// follows insertion time only.
+	cache.Get("1")
+	cache.Set("3", "three", 0)
+
+	_, exists := cache.Get("1")
+	require.False(t, exists, "LRC should evict by creation order regardless of access")
+
+	_, exists = cache.Get("2")
+	require.True(t, exists)
+
+	_, exists = cache.Get("3")
+	require.True(t, exists)
+}
+
+func TestCache_LFUPolicy_EvictsLeastFrequentlyUsed(t *testing.T) {
+	cache := NewWithConfig[string, string](Config[string, string]{
+		MaxSize:    2,
+		DefaultTTL: time.Hour,
+		Policy:     NewLFUPolicy[string, string](2),
+	})
+	defer func() {
+		cache.Stop()
+		cache.Clear()
+	}()
+
+	cache.Set("1", "one", 0)
+	cache.Set("2", "two", 0)
+
+	// Access "1" repeatedly so "2" becomes the least-frequently-used entry.
+	cache.Get("1")
+	cache.Get("1")
+
+	cache.Set("3", "three", 0)
+
+	_, exists := cache.Get("2")
+	require.False(t, exists, "LFU should evict the least-frequently-used entry")
+
+	_, exists = cache.Get("1")
+	require.True(t, exists)
+
+	_, exists = cache.Get("3")
+	require.True(t, exists)
+}
+
+func TestCache_ByteSizePolicy_EvictsByTotalSize(t *testing.T) {
+	cache := NewWithConfig[string, string](Config[string, string]{
+		DefaultTTL: time.Hour,
+		Policy: NewByteSizePolicy[string, string](10, func(value string) int {
+			return len(value)
+		}),
+	})
+	defer func() {
+		cache.Stop()
+		cache.Clear()
+	}()
+
+	cache.Set("1", "12345", 0) // 5 bytes, total 5
+	cache.Set("2", "12345", 0) // 5 bytes, total 10
+	cache.Set("3", "123", 0)   // 3 bytes, total 13 > 10: evicts "1"
+
+	_, exists := cache.Get("1")
+	require.False(t, exists, "oldest entry should be evicted once over the byte budget")
+
+	_, exists = cache.Get("2")
+	require.True(t, exists)
+
+	_, exists = cache.Get("3")
+	require.True(t, exists)
+
+	require.Equal(t, 2, cache.Len())
+}
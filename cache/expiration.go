@@ -0,0 +1,75 @@
+package cache
+
+import "container/heap"
+
+// expirationEntry tracks when a key is due to expire and its position in the
+// owning expirationQueue, so it can be removed or re-prioritized in O(log N).
+type expirationEntry[K comparable] struct {
+	key        K
+	expiration int64
+	index      int
+}
+
+// expirationQueue is a container/heap min-heap ordered by expiration, giving
+// the janitor O(log N) maintenance and O(1) access to the soonest-expiring
+// entry instead of an O(N) scan of every item on every tick.
+type expirationQueue[K comparable] []*expirationEntry[K]
+
+func (q expirationQueue[K]) Len() int { return len(q) }
+
+func (q expirationQueue[K]) Less(i, j int) bool { return q[i].expiration < q[j].expiration }
+
+func (q expirationQueue[K]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *expirationQueue[K]) Push(x any) {
+	entry := x.(*expirationEntry[K])
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *expirationQueue[K]) Pop() any {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*q = old[:n-1]
+	return entry
+}
+
+// trackExpirationLocked replaces any existing heap entry for key with one at
+// exp, or removes it entirely if exp is 0 (no expiration). Callers must hold
+// c.lock.
+func (c *Cache[K, V]) trackExpirationLocked(key K, exp int64) {
+	c.untrackExpirationLocked(key)
+	if exp <= 0 {
+		return
+	}
+	entry := &expirationEntry[K]{key: key, expiration: exp}
+	heap.Push(&c.expQueue, entry)
+	c.expIndex[key] = entry
+}
+
+// untrackExpirationLocked removes key's heap entry, if any. Callers must hold
+// c.lock.
+func (c *Cache[K, V]) untrackExpirationLocked(key K) {
+	entry, exists := c.expIndex[key]
+	if !exists {
+		return
+	}
+	heap.Remove(&c.expQueue, entry.index)
+	delete(c.expIndex, key)
+}
+
+// nextExpirationLocked returns the soonest pending expiration, if any.
+// Callers must hold c.lock.
+func (c *Cache[K, V]) nextExpirationLocked() (int64, bool) {
+	if len(c.expQueue) == 0 {
+		return 0, false
+	}
+	return c.expQueue[0].expiration, true
+}
@@ -0,0 +1,16 @@
+package cache
+
+// AnyCache is the pre-generics shape of Cache (string keys, any values). It
+// lets callers that have not yet migrated to explicit type parameters keep
+// compiling while they adopt Cache[K, V] at their own pace.
+type AnyCache = Cache[string, any]
+
+// NewAnyCache is equivalent to New[string, any]().
+func NewAnyCache() *AnyCache {
+	return New[string, any]()
+}
+
+// NewAnyCacheWithConfig is equivalent to NewWithConfig[string, any](config).
+func NewAnyCacheWithConfig(config Config[string, any]) *AnyCache {
+	return NewWithConfig[string, any](config)
+}
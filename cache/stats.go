@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"sync/atomic"
+)
+
+// Stats is a point-in-time snapshot of a Cache's counters.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Insertions  uint64
+	Deletions   uint64
+	Expirations uint64
+	Evictions   uint64
+}
+
+// cacheStats holds the atomic counters backing Stats. Embedded in Cache by
+// value; fields are updated in place from Get, Set, delete, and the janitor.
+type cacheStats struct {
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	insertions  atomic.Uint64
+	deletions   atomic.Uint64
+	expirations atomic.Uint64
+	evictions   atomic.Uint64
+}
+
+// Stats returns a snapshot of the cache's hit/miss/insertion/eviction counters.
+func (c *Cache[K, V]) Stats() Stats {
+	return Stats{
+		Hits:        c.stats.hits.Load(),
+		Misses:      c.stats.misses.Load(),
+		Insertions:  c.stats.insertions.Load(),
+		Deletions:   c.stats.deletions.Load(),
+		Expirations: c.stats.expirations.Load(),
+		Evictions:   c.stats.evictions.Load(),
+	}
+}
+
+// Keys returns a snapshot of every key currently stored in the cache, in no
+// particular order.
+func (c *Cache[K, V]) Keys() []K {
+	keys := make([]K, 0)
+	c.itemsMap().Range(func(k, _ any) bool {
+		keys = append(keys, k.(K))
+		return true
+	})
+	return keys
+}
+
+// ItemCount is equivalent to Len; it's provided alongside Keys and Peek for
+// callers building dashboards around the cache's contents.
+func (c *Cache[K, V]) ItemCount() int {
+	return c.Len()
+}
+
+// Peek returns the value for key, if present and unexpired, without
+// promoting it in the eviction policy's ordering. Useful for observing
+// cache state without perturbing recency or frequency.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	var zero V
+
+	itemObj, exists := c.itemsMap().Load(key)
+	if !exists {
+		return zero, false
+	}
+
+	item := itemObj.(Item[V])
+	if item.Expiration > 0 && c.clock.Now().UnixNano() > item.Expiration {
+		return zero, false
+	}
+
+	return item.Value, true
+}
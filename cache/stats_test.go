@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_Stats(t *testing.T) {
+	cache := NewWithConfig[string, string](Config[string, string]{
+		MaxSize:    1,
+		DefaultTTL: time.Hour,
+	})
+	defer cache.Stop()
+
+	cache.Set("1", "one", 0)
+	cache.Set("2", "two", 0) // evicts "1" for capacity
+
+	_, exists := cache.Get("2")
+	require.True(t, exists)
+
+	_, exists = cache.Get("1")
+	require.False(t, exists)
+
+	cache.Delete("2")
+
+	stats := cache.Stats()
+	require.Equal(t, uint64(2), stats.Insertions)
+	require.Equal(t, uint64(1), stats.Hits)
+	require.Equal(t, uint64(1), stats.Misses)
+	require.Equal(t, uint64(1), stats.Evictions)
+	require.Equal(t, uint64(1), stats.Deletions)
+}
+
+func TestCache_KeysItemCountAndPeek(t *testing.T) {
+	cache := New[string, string]()
+	defer cache.Stop()
+
+	cache.Set("1", "one", 0)
+	cache.Set("2", "two", 0)
+
+	require.ElementsMatch(t, []string{"1", "2"}, cache.Keys())
+	require.Equal(t, 2, cache.ItemCount())
+
+	val, exists := cache.Peek("1")
+	require.True(t, exists)
+	require.Equal(t, "one", val)
+
+	// Peek must not count as a hit or promote the entry.
+	stats := cache.Stats()
+	require.Equal(t, uint64(0), stats.Hits)
+}